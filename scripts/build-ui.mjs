@@ -1,4 +1,4 @@
-import { build } from "esbuild";
+import { build, context } from "esbuild";
 import fs from "node:fs/promises";
 import path from "node:path";
 import process from "node:process";
@@ -6,8 +6,9 @@ import process from "node:process";
 const entry = path.resolve("src/ui/app.ts");
 const out = path.resolve("public/app.js");
 const outXtermCss = path.resolve("public/xterm.css");
+const watch = process.argv.includes("--watch");
 
-await build({
+const options = {
   entryPoints: [entry],
   outfile: out,
   bundle: true,
@@ -20,10 +21,38 @@ await build({
   define: {
     "process.env.NODE_ENV": JSON.stringify(process.env.NODE_ENV ?? "development"),
   },
-});
+};
 
 // xterm ships its own CSS; keep it in /public so our minimal static server can serve it.
 await fs.copyFile(path.resolve("node_modules/@xterm/xterm/css/xterm.css"), outXtermCss);
 
-// eslint-disable-next-line no-console
-console.log(`Built ${path.relative(process.cwd(), out)}`);
+if (watch) {
+  // Report every incremental build on its own line so scripts/dev.mjs can tell when output is on disk.
+  const reportPlugin = {
+    name: "report",
+    setup(b) {
+      b.onEnd((result) => {
+        if (result.errors.length > 0) {
+          // eslint-disable-next-line no-console
+          console.log(`Build failed with ${result.errors.length} error(s)`);
+        } else {
+          // eslint-disable-next-line no-console
+          console.log(`Built ${path.relative(process.cwd(), out)}`);
+        }
+      });
+    },
+  };
+  const ctx = await context({ ...options, plugins: [reportPlugin] });
+  await ctx.watch();
+  const stop = async () => {
+    await ctx.dispose();
+    process.exit(0);
+  };
+  process.on("SIGINT", stop);
+  process.on("SIGTERM", stop);
+} else {
+  await build(options);
+
+  // eslint-disable-next-line no-console
+  console.log(`Built ${path.relative(process.cwd(), out)}`);
+}
@@ -3,6 +3,7 @@ import fs from "node:fs";
 import net from "node:net";
 import path from "node:path";
 import process from "node:process";
+import readline from "node:readline";
 
 const repo = process.cwd();
 const binExt = process.platform === "win32" ? ".cmd" : "";
@@ -13,6 +14,18 @@ const DEFAULT_PORT = 4821;
 const host = process.env.HOST ?? DEFAULT_HOST;
 const requestedPort = Number(process.env.PORT ?? process.env.APP_PORT ?? String(DEFAULT_PORT));
 const port = Number.isInteger(requestedPort) && requestedPort > 0 ? requestedPort : DEFAULT_PORT;
+// "rebuild" re-runs scripts/build-ui.mjs on every src/ui/ change; "watch" keeps a
+// long-running esbuild watch child that rebuilds incrementally.
+const uiBuildStrategy = process.env.AGMUX_DEV_UI_BUILD === "watch" ? "watch" : "rebuild";
+// Opt-in extra attempts for a failed rebuild-mode UI build, e.g. when a file was still
+// being written. Off by default: real compile errors would just repeat their diagnostics.
+const DEFAULT_UI_BUILD_RETRIES = 0;
+const UI_BUILD_RETRY_BASE_MS = 500;
+const requestedUiBuildRetries = Number(process.env.AGMUX_DEV_UI_BUILD_RETRIES ?? String(DEFAULT_UI_BUILD_RETRIES));
+const uiBuildRetries =
+  Number.isInteger(requestedUiBuildRetries) && requestedUiBuildRetries >= 0
+    ? requestedUiBuildRetries
+    : DEFAULT_UI_BUILD_RETRIES;
 
 function getPortListeners(p) {
   try {
@@ -79,37 +92,104 @@ function spawnChild(cmd, args, label, extraEnv = {}) {
   return child;
 }
 
-await checkPortAvailable(host, port);
+function startUiRebuildOnChange() {
+  // Build UI once before starting the server.
+  console.log("[dev] Building UI...");
+  execSync("node scripts/build-ui.mjs", { cwd: repo, stdio: "inherit" });
 
-// Build UI once before starting the server.
-console.log("[dev] Building UI...");
-execSync("node scripts/build-ui.mjs", { cwd: repo, stdio: "inherit" });
-
-// Watch src/ui/ for changes and rebuild UI automatically.
-let rebuildTimer = null;
-function scheduleRebuild() {
-  if (rebuildTimer) return;
-  rebuildTimer = setTimeout(() => {
-    rebuildTimer = null;
-    console.log("[dev] UI source changed, rebuilding...");
+  // Watch src/ui/ for changes and rebuild UI automatically.
+  let rebuildTimer = null;
+  function rebuild(attempt) {
     try {
       execSync("node scripts/build-ui.mjs", { cwd: repo, stdio: "inherit" });
+      if (attempt > 0) console.log(`[dev] UI rebuild succeeded on retry ${attempt}/${uiBuildRetries}`);
     } catch (err) {
-      console.error("[dev] UI rebuild failed:", err.message);
+      if (attempt < uiBuildRetries) {
+        const delay = UI_BUILD_RETRY_BASE_MS * 2 ** attempt;
+        console.warn(`[dev] UI rebuild failed, retrying in ${delay}ms (retry ${attempt + 1}/${uiBuildRetries})`);
+        // Changes arriving during the backoff are picked up by the retry itself.
+        rebuildTimer = setTimeout(() => {
+          rebuildTimer = null;
+          rebuild(attempt + 1);
+        }, delay);
+        return;
+      }
+      console.error(`[dev] UI rebuild failed permanently after ${attempt + 1} attempt(s):`, err.message);
     }
-  }, 200);
+  }
+
+  function scheduleRebuild() {
+    if (rebuildTimer) return;
+    rebuildTimer = setTimeout(() => {
+      rebuildTimer = null;
+      console.log("[dev] UI source changed, rebuilding...");
+      rebuild(0);
+    }, 200);
+  }
+
+  try {
+    const uiDir = path.join(repo, "src", "ui");
+    fs.watch(uiDir, { recursive: true }, (_event, filename) => {
+      if (!filename) return;
+      if (filename.endsWith(".ts") || filename.endsWith(".tsx") || filename.endsWith(".css")) {
+        scheduleRebuild();
+      }
+    });
+  } catch {
+    console.warn("[dev] Could not watch src/ui/ for changes");
+  }
 }
 
-try {
-  const uiDir = path.join(repo, "src", "ui");
-  fs.watch(uiDir, { recursive: true }, (_event, filename) => {
-    if (!filename) return;
-    if (filename.endsWith(".ts") || filename.endsWith(".tsx") || filename.endsWith(".css")) {
-      scheduleRebuild();
+async function startUiWatchChild() {
+  console.log("[dev] Starting UI build in watch mode...");
+  const child = spawn(process.execPath, ["scripts/build-ui.mjs", "--watch"], {
+    cwd: repo,
+    stdio: ["ignore", "pipe", "inherit"],
+    env: process.env,
+  });
+
+  // The watcher prints "Built <file>" after each successful build and "Build failed ..."
+  // otherwise. Open tabs still reload on their own once the asset poller sees new ETags.
+  let builds = 0;
+  let resolveFirstBuild;
+  let rejectFirstBuild;
+  const firstBuild = new Promise((resolve, reject) => {
+    resolveFirstBuild = resolve;
+    rejectFirstBuild = reject;
+  });
+  readline.createInterface({ input: child.stdout }).on("line", (line) => {
+    console.log(`[ui] ${line}`);
+    if (builds === 0 && line.startsWith("Build failed")) {
+      // Match the rebuild strategy, which exits when the initial build fails.
+      child.kill("SIGINT");
+      rejectFirstBuild(new Error("Initial UI build failed; fix the errors above and restart"));
+      return;
     }
+    if (!line.startsWith("Built ")) return;
+    builds += 1;
+    if (builds === 1) resolveFirstBuild();
+    else console.log("[dev] UI rebuilt, open tabs will reload");
+  });
+  child.on("exit", (code, signal) => {
+    rejectFirstBuild(new Error(`UI watch exited before first build (${signal ?? code})`));
   });
-} catch {
-  console.warn("[dev] Could not watch src/ui/ for changes");
+
+  await firstBuild;
+  return child;
+}
+
+await checkPortAvailable(host, port);
+
+let uiWatch = null;
+if (uiBuildStrategy === "watch") {
+  try {
+    uiWatch = await startUiWatchChild();
+  } catch (err) {
+    console.error(`[dev] ${err.message}`);
+    process.exit(1);
+  }
+} else {
+  startUiRebuildOnChange();
 }
 
 console.log("[dev] Starting TypeScript compiler in watch mode...");
@@ -165,6 +245,7 @@ if (process.env.AGMUX_NO_OPEN !== "1") {
 }
 
 function shutdown(code) {
+  if (uiWatch && !uiWatch.killed) uiWatch.kill("SIGINT");
   if (!tsc.killed) tsc.kill("SIGINT");
   if (!server.killed) server.kill("SIGINT");
   process.exit(code);
@@ -178,5 +259,11 @@ server.on("exit", (code) => {
   if (code && code !== 0) shutdown(code);
 });
 
+uiWatch?.on("exit", (code, signal) => {
+  // Without the watcher UI changes would silently stop rebuilding, so treat any exit as fatal.
+  console.error(`[dev] UI watch exited unexpectedly (${signal ? `signal ${signal}` : `code ${code}`}), stopping`);
+  shutdown(code || 1);
+});
+
 process.on("SIGINT", () => shutdown(0));
 process.on("SIGTERM", () => shutdown(0));
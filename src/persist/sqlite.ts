@@ -310,6 +310,12 @@ export class SqliteStore {
     return result;
   }
 
+  /** Runs a trivial query; throws if the database is closed or unreadable. */
+  ping(): boolean {
+    const row = this.db.prepare(`select 1 as ok;`).get() as { ok: number } | undefined;
+    return row?.ok === 1;
+  }
+
   deleteInputHistory(sessionId: string): void {
     this.db.prepare(`delete from input_history where session_id = ?;`).run(sessionId);
   }
@@ -6,6 +6,7 @@ import { LogSessionDiscovery } from "./logSessions.js";
 import { SqliteStore } from "./persist/sqlite.js";
 import { createAgentSessionService } from "./server/agent-sessions.js";
 import { registerAuthHook } from "./server/auth.js";
+import { registerCorsHook } from "./server/cors.js";
 import {
   AGMUX_SESSION,
   AUTH_ENABLED,
@@ -24,10 +25,13 @@ import {
   READINESS_TRACE_MAX,
   REPO_ROOT,
   TRIGGERS_PATH,
+  UI_OVERRIDE_DIR,
   assertLoopbackHostAllowed,
+  assertUiOverrideDirAllowed,
 } from "./server/config.js";
 import { createRuntime } from "./server/pty-runtime.js";
 import { registerAgentRoutes } from "./server/routes/agents.js";
+import { registerHealthRoutes } from "./server/routes/health.js";
 import { registerPtyRoutes } from "./server/routes/ptys.js";
 import { registerSettingsRoutes } from "./server/routes/settings.js";
 import { registerStaticRoutes } from "./server/routes/static.js";
@@ -39,6 +43,7 @@ import { createWorktreeService } from "./server/worktrees.js";
 import { registerWs } from "./server/ws.js";
 
 assertLoopbackHostAllowed();
+assertUiOverrideDirAllowed();
 
 const fastify = Fastify({
   logger: { level: LOG_LEVEL },
@@ -75,6 +80,7 @@ const runtime = createRuntime({
   refreshWorktrees: () => worktrees.refreshCache(),
 });
 
+registerCorsHook(fastify);
 registerAuthHook(fastify);
 
 registerAgentRoutes({
@@ -101,7 +107,13 @@ registerPtyRoutes({
   agmuxSession: AGMUX_SESSION,
 });
 registerTriggerRoutes({ fastify, loadTriggersAndBroadcast: runtime.loadTriggersAndBroadcast });
-registerStaticRoutes({ fastify, publicDir: PUBLIC_DIR });
+registerHealthRoutes({
+  fastify,
+  readinessChecks: {
+    db: async () => store.ping(),
+  },
+});
+registerStaticRoutes({ fastify, publicDir: PUBLIC_DIR, overrideDir: UI_OVERRIDE_DIR });
 
 registerWs({
   fastify,
@@ -132,6 +144,9 @@ const appUrl = `http://${HOST === "0.0.0.0" || HOST === "::" ? "127.0.0.1" : HOS
 const appUrlWithToken = AUTH_ENABLED ? `${appUrl}/?token=${encodeURIComponent(AUTH_TOKEN)}` : appUrl;
 console.log(`[agmux] Ready at ${appUrl}`);
 console.log(`[agmux] Log level: ${LOG_LEVEL}`);
+if (UI_OVERRIDE_DIR) {
+  console.log(`[agmux] Serving UI overrides from ${UI_OVERRIDE_DIR}`);
+}
 if (AUTH_ENABLED) {
   console.log(`[agmux] Auth token enabled via AGMUX_TOKEN_ENABLED=1 (${AUTH_TOKEN_SOURCE}).`);
   console.log(`[agmux] Token: ${AUTH_TOKEN}`);
@@ -1,5 +1,6 @@
 import { execFileSync } from "node:child_process";
 import { randomBytes } from "node:crypto";
+import fs from "node:fs";
 import path from "node:path";
 import process from "node:process";
 
@@ -23,6 +24,8 @@ export const DEFAULT_PORT = 4821;
 const requestedPort = Number(process.env.PORT ?? String(DEFAULT_PORT));
 export const PORT = Number.isInteger(requestedPort) && requestedPort > 0 ? requestedPort : DEFAULT_PORT;
 export const PUBLIC_DIR = path.resolve("public");
+/** Optional directory whose files take precedence over PUBLIC_DIR when serving the UI. */
+export const UI_OVERRIDE_DIR = process.env.AGMUX_UI_DIR?.trim() ? path.resolve(process.env.AGMUX_UI_DIR.trim()) : null;
 export const DB_PATH = process.env.DB_PATH ?? path.resolve("data/agmux.db");
 export const TRIGGERS_PATH = process.env.TRIGGERS_PATH ?? path.resolve("triggers/index.js");
 export const AUTH_ENABLED = /^(1|true|yes|on)$/i.test((process.env.AGMUX_TOKEN_ENABLED ?? "").trim());
@@ -51,6 +54,31 @@ function originHostForBindHost(host: string): string | null {
   return normalized;
 }
 
+function parseListEnv(raw: string | undefined): string[] {
+  return (raw ?? "")
+    .split(",")
+    .map((v) => v.trim())
+    .filter((v) => v.length > 0);
+}
+
+/**
+ * Cross-origin callers allowed on /api/*. Empty keeps the API same-origin only.
+ * "*" is only honoured with token auth on, since it would otherwise open the API to any page.
+ */
+export const CORS_ALLOWED_ORIGINS = new Set(
+  parseListEnv(process.env.AGMUX_CORS_ORIGINS)
+    .map((v) => v.toLowerCase())
+    .filter((v) => {
+      if (v !== "*" || AUTH_ENABLED) return true;
+      console.error('[agmux] Ignoring AGMUX_CORS_ORIGINS="*": wildcard CORS requires AGMUX_TOKEN_ENABLED=1.');
+      return false;
+    }),
+);
+const corsMethods = parseListEnv(process.env.AGMUX_CORS_METHODS).map((v) => v.toUpperCase());
+export const CORS_ALLOWED_METHODS = corsMethods.length > 0 ? corsMethods : ["GET", "POST", "PUT", "PATCH", "DELETE"];
+const corsHeaders = parseListEnv(process.env.AGMUX_CORS_HEADERS);
+export const CORS_ALLOWED_HEADERS = corsHeaders.length > 0 ? corsHeaders : ["Content-Type", "Authorization", "X-Agmux-Token"];
+
 const bindOriginHost = originHostForBindHost(HOST);
 export const WS_ALLOWED_ORIGINS = new Set(
   [
@@ -58,10 +86,9 @@ export const WS_ALLOWED_ORIGINS = new Set(
     `http://localhost:${PORT}`,
     `http://[::1]:${PORT}`,
     ...(bindOriginHost ? [`http://${bindOriginHost}:${PORT}`] : []),
-    ...(process.env.AGMUX_ALLOWED_ORIGINS ?? "")
-      .split(",")
-      .map((v) => v.trim())
-      .filter((v) => v.length > 0),
+    ...parseListEnv(process.env.AGMUX_ALLOWED_ORIGINS),
+    // Literal CORS origins may also open /ws; "*" never applies to /ws.
+    ...[...CORS_ALLOWED_ORIGINS].filter((v) => v !== "*"),
   ].map((v) => v.toLowerCase()),
 );
 export const DEFAULT_BASE_BRANCH = "main";
@@ -80,3 +107,19 @@ export function assertLoopbackHostAllowed(): void {
     );
   }
 }
+
+export function assertUiOverrideDirAllowed(): void {
+  if (!UI_OVERRIDE_DIR) return;
+  if (UI_OVERRIDE_DIR === path.parse(UI_OVERRIDE_DIR).root) {
+    throw new Error(`Refusing to serve the filesystem root as AGMUX_UI_DIR ("${UI_OVERRIDE_DIR}").`);
+  }
+  let isDirectory = false;
+  try {
+    isDirectory = fs.statSync(UI_OVERRIDE_DIR).isDirectory();
+  } catch {
+    // reported below
+  }
+  if (!isDirectory) {
+    throw new Error(`AGMUX_UI_DIR "${UI_OVERRIDE_DIR}" is not a directory.`);
+  }
+}
@@ -0,0 +1,41 @@
+import type { FastifyInstance } from "fastify";
+import { CORS_ALLOWED_HEADERS, CORS_ALLOWED_METHODS, CORS_ALLOWED_ORIGINS } from "./config.js";
+
+export type CorsConfig = {
+  origins: ReadonlySet<string>;
+  methods: readonly string[];
+  headers: readonly string[];
+};
+
+const DEFAULT_CORS_CONFIG: CorsConfig = {
+  origins: CORS_ALLOWED_ORIGINS,
+  methods: CORS_ALLOWED_METHODS,
+  headers: CORS_ALLOWED_HEADERS,
+};
+
+const PREFLIGHT_MAX_AGE_SECONDS = 600;
+
+export function isCorsOriginAllowed(config: CorsConfig, origin: string | undefined): boolean {
+  if (!origin || origin.length === 0) return false;
+  return config.origins.has("*") || config.origins.has(origin.toLowerCase());
+}
+
+/**
+ * Adds CORS headers for allowed origins on /api/* and answers preflight requests.
+ * With no configured origins nothing is registered, so the API stays same-origin only.
+ */
+export function registerCorsHook(fastify: FastifyInstance, config: CorsConfig = DEFAULT_CORS_CONFIG): void {
+  if (config.origins.size === 0) return;
+  fastify.addHook("onRequest", async (req, reply) => {
+    if (!(req.raw.url ?? "").startsWith("/api/")) return;
+    reply.header("Vary", "Origin");
+    const origin = req.headers.origin;
+    if (!origin || !isCorsOriginAllowed(config, origin)) return;
+    reply.header("Access-Control-Allow-Origin", origin);
+    if ((req.raw.method ?? "GET").toUpperCase() !== "OPTIONS") return;
+    reply.header("Access-Control-Allow-Methods", config.methods.join(", "));
+    reply.header("Access-Control-Allow-Headers", config.headers.join(", "));
+    reply.header("Access-Control-Max-Age", String(PREFLIGHT_MAX_AGE_SECONDS));
+    return reply.code(204).send();
+  });
+}
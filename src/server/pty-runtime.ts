@@ -39,6 +39,11 @@ export type RuntimeDeps = {
 
 type ReadinessTraceEntry = PtyReadyEvent & { seq: number };
 
+/** Outcome of a trigger reload; on failure the last good triggers stay active. */
+export type TriggerReloadResult =
+  | { ok: true; version: number; count: number }
+  | { ok: false; error: string };
+
 export function createRuntime(deps: RuntimeDeps) {
   const { store, logger, agentSessions, readinessTraceMax, readinessTraceLog, triggersPath, agmuxSession, refreshWorktrees } = deps;
   const ptys = new PtyManager();
@@ -315,11 +320,12 @@ export function createRuntime(deps: RuntimeDeps) {
     }
   }
 
-  async function loadTriggersAndBroadcast(reason: string): Promise<void> {
+  async function loadTriggersAndBroadcast(reason: string): Promise<TriggerReloadResult> {
     try {
       const { triggers, version } = await triggerLoader.load();
       triggerEngine.setTriggers(triggers);
       logger.info({ reason, version, count: triggers.length }, "Triggers loaded");
+      return { ok: true, version, count: triggers.length };
     } catch (err) {
       triggerEngine.setTriggers(triggerLoader.lastGoodTriggers());
       const message = err instanceof Error ? err.message : String(err);
@@ -331,6 +337,7 @@ export function createRuntime(deps: RuntimeDeps) {
         ts: Date.now(),
         message,
       });
+      return { ok: false, error: message };
     }
   }
 
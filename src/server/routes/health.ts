@@ -0,0 +1,33 @@
+import type { FastifyInstance } from "fastify";
+
+type HealthRoutesDeps = {
+  fastify: FastifyInstance;
+  /** Named dependency checks reported by /readyz; a throwing check counts as failed. */
+  readinessChecks: Record<string, () => Promise<boolean>>;
+};
+
+export function registerHealthRoutes(deps: HealthRoutesDeps): void {
+  const { fastify, readinessChecks } = deps;
+  const startedAt = Date.now();
+
+  // Liveness: answering at all means the event loop and HTTP server are up.
+  fastify.get("/healthz", async () => {
+    return { ok: true, uptimeMs: Date.now() - startedAt };
+  });
+
+  fastify.get("/readyz", async (_req, reply) => {
+    const entries = await Promise.all(
+      Object.entries(readinessChecks).map(async ([name, check]) => {
+        try {
+          return [name, await check()] as const;
+        } catch {
+          return [name, false] as const;
+        }
+      }),
+    );
+    const checks = Object.fromEntries(entries);
+    const ok = entries.every(([, passed]) => passed);
+    if (!ok) reply.code(503);
+    return { ok, checks };
+  });
+}
@@ -1,30 +1,33 @@
-import type { FastifyInstance } from "fastify";
+import path from "node:path";
+import type { FastifyInstance, FastifyReply, FastifyRequest } from "fastify";
 import { serveStatic } from "../static.js";
 
 type StaticRoutesDeps = {
   fastify: FastifyInstance;
   publicDir: string;
+  /** Files here shadow publicDir; anything missing falls back to publicDir. */
+  overrideDir?: string | null;
 };
 
 export function registerStaticRoutes(deps: StaticRoutesDeps): void {
-  const { fastify, publicDir } = deps;
+  const { fastify, publicDir, overrideDir } = deps;
 
-  fastify.get("/", async (_req, reply) => {
-    const r = await serveStatic(publicDir, "index.html");
-    if (!r) return reply.code(404).send("not found");
-    reply.header("Cache-Control", "no-store");
-    reply.header("ETag", r.etag);
-    reply.header("Last-Modified", r.lastModified);
-    return reply.type(r.type).send(r.data);
-  });
+  async function lookup(rel: string) {
+    if (overrideDir) {
+      const r = await serveStatic(overrideDir, rel);
+      if (r) return r;
+    }
+    return serveStatic(publicDir, rel);
+  }
 
-  fastify.get("/:file", async (req, reply) => {
-    const file = (req.params as any).file as string;
-    if (file.startsWith("api")) return reply.code(404).send("not found");
-    const r = await serveStatic(publicDir, file);
+  async function sendFile(req: FastifyRequest, reply: FastifyReply, rel: string) {
+    let r = await lookup(rel);
+    // SPA fallback: extensionless paths that are not files resolve to the UI shell.
+    if (!r && path.extname(rel) === "") r = await lookup("index.html");
     if (!r) return reply.code(404).send("not found");
 
-    reply.header("Cache-Control", "no-store");
+    // Always revalidate, but let unchanged assets come back as 304 via the ETag.
+    reply.header("Cache-Control", "no-cache");
     reply.header("ETag", r.etag);
     reply.header("Last-Modified", r.lastModified);
 
@@ -32,5 +35,16 @@ export function registerStaticRoutes(deps: StaticRoutesDeps): void {
     if (typeof inm === "string" && inm === r.etag) return reply.code(304).send();
 
     return reply.type(r.type).send(r.data);
+  }
+
+  fastify.get("/", async (req, reply) => sendFile(req, reply, "index.html"));
+
+  fastify.get("/*", async (req, reply) => {
+    const rel = (req.params as Record<string, string>)["*"] ?? "";
+    // Unknown API routes keep Fastify's JSON 404 instead of the static-file one.
+    if (rel === "api" || rel.startsWith("api/")) return reply.callNotFound();
+    // Dot paths are never served, and must not fall through to the SPA shell either.
+    if (rel.split("/").some((seg) => seg.startsWith("."))) return reply.code(404).send("not found");
+    return sendFile(req, reply, rel);
   });
 }
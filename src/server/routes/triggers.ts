@@ -1,15 +1,21 @@
 import type { FastifyInstance } from "fastify";
+import type { TriggerReloadResult } from "../pty-runtime.js";
 
 type TriggerRoutesDeps = {
   fastify: FastifyInstance;
-  loadTriggersAndBroadcast: (reason: string) => Promise<void>;
+  loadTriggersAndBroadcast: (reason: string) => Promise<TriggerReloadResult>;
 };
 
 export function registerTriggerRoutes(deps: TriggerRoutesDeps): void {
   const { fastify, loadTriggersAndBroadcast } = deps;
 
-  fastify.post("/api/triggers/reload", async () => {
-    await loadTriggersAndBroadcast("manual");
-    return { ok: true };
+  fastify.post("/api/triggers/reload", async (_req, reply) => {
+    const result = await loadTriggersAndBroadcast("manual");
+    if (!result.ok) {
+      // The previous triggers are still active; surface that instead of reporting success.
+      reply.code(500);
+      return { error: result.error };
+    }
+    return { ok: true, version: result.version, count: result.count };
   });
 }
@@ -3,10 +3,39 @@ import path from "node:path";
 
 export type StaticResponse = { data: Buffer; type: string; etag: string; lastModified: string };
 
+const CONTENT_TYPES: Record<string, string> = {
+  ".html": "text/html; charset=utf-8",
+  ".css": "text/css; charset=utf-8",
+  ".js": "text/javascript; charset=utf-8",
+  ".map": "application/json; charset=utf-8",
+  ".json": "application/json; charset=utf-8",
+  ".webmanifest": "application/manifest+json; charset=utf-8",
+  ".txt": "text/plain; charset=utf-8",
+  ".svg": "image/svg+xml",
+  ".png": "image/png",
+  ".jpg": "image/jpeg",
+  ".jpeg": "image/jpeg",
+  ".gif": "image/gif",
+  ".webp": "image/webp",
+  ".ico": "image/x-icon",
+  ".woff": "font/woff",
+  ".woff2": "font/woff2",
+};
+
+export function contentTypeForPath(filePath: string): string {
+  return CONTENT_TYPES[path.extname(filePath).toLowerCase()] ?? "application/octet-stream";
+}
+
 export async function serveStatic(publicDir: string, rel: string): Promise<StaticResponse | null> {
   const safe = path.normalize(rel).replace(/^(\\.\\.[/\\\\])+/, "");
+  // Never serve dotfiles or dot-directories (.git, .env, ...) from the UI dirs.
+  if (safe.split(/[/\\]/).some((seg) => seg.startsWith("."))) return null;
   const filePath = path.join(publicDir, safe);
-  if (!filePath.startsWith(publicDir)) return null;
+  // Compare via path.relative so sibling dirs sharing the prefix are not contained.
+  const relToRoot = path.relative(publicDir, filePath);
+  if (!relToRoot || relToRoot === ".." || relToRoot.startsWith(`..${path.sep}`) || path.isAbsolute(relToRoot)) {
+    return null;
+  }
   let st: Awaited<ReturnType<typeof fs.stat>>;
   try {
     st = await fs.stat(filePath);
@@ -24,17 +53,7 @@ export async function serveStatic(publicDir: string, rel: string): Promise<Stati
     if (code === "ENOENT" || code === "ENOTDIR" || code === "EISDIR") return null;
     throw err;
   }
-  const ext = path.extname(filePath).toLowerCase();
-  const type =
-    ext === ".html"
-      ? "text/html; charset=utf-8"
-      : ext === ".css"
-        ? "text/css; charset=utf-8"
-        : ext === ".js"
-          ? "text/javascript; charset=utf-8"
-          : ext === ".map"
-            ? "application/json; charset=utf-8"
-            : "application/octet-stream";
+  const type = contentTypeForPath(filePath);
   const etag = `W/"${st.size}-${Math.floor(st.mtimeMs)}"`;
   const lastModified = st.mtime.toUTCString();
   return { data, type, etag, lastModified };
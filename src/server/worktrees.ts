@@ -111,7 +111,18 @@ export function createWorktreeService(deps: WorktreeServiceDeps) {
         });
       });
     } catch {
-      // ignore
+      // An unborn branch (no commits yet) has no HEAD commit to resolve, but
+      // HEAD still names the branch symbolically.
+      try {
+        branch = await new Promise<string>((resolve, reject) => {
+          execFile("git", ["symbolic-ref", "--short", "HEAD"], { cwd: resolved }, (err, stdout) => {
+            if (err) reject(err);
+            else resolve(stdout.trim());
+          });
+        });
+      } catch {
+        // ignore
+      }
     }
     return { dirty, branch, changes: changes.slice(0, 20) };
   }
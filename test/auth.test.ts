@@ -1,8 +1,9 @@
-import { describe, expect, it } from "vitest";
+import { afterEach, describe, expect, it, vi } from "vitest";
 import {
   parseTokenFromHeaders,
   parseTokenFromUrl,
   isTokenValid,
+  isWsOriginAllowed,
   requestNeedsToken,
 } from "../src/server/auth.js";
 
@@ -88,3 +89,40 @@ describe("requestNeedsToken (auth disabled in test env)", () => {
     expect(requestNeedsToken("OPTIONS", "/api/ptys")).toBe(false);
   });
 });
+
+describe("isWsOriginAllowed", () => {
+  afterEach(() => {
+    vi.restoreAllMocks();
+    vi.unstubAllEnvs();
+    vi.resetModules();
+  });
+
+  it("allows same-host origins and requests without an Origin header", () => {
+    expect(isWsOriginAllowed(undefined)).toBe(true);
+    expect(isWsOriginAllowed("http://127.0.0.1:4821")).toBe(true);
+    expect(isWsOriginAllowed("HTTP://LOCALHOST:4821")).toBe(true);
+  });
+
+  it("rejects other origins", () => {
+    expect(isWsOriginAllowed("http://evil.example")).toBe(false);
+    expect(isWsOriginAllowed("http://127.0.0.1:9999")).toBe(false);
+  });
+
+  it("accepts literal configured origins but never treats * as a wildcard", async () => {
+    vi.stubEnv("AGMUX_ALLOWED_ORIGINS", "*,http://tools.local:3000");
+    vi.stubEnv("AGMUX_CORS_ORIGINS", "*,http://dash.local:5173");
+    vi.resetModules();
+    const auth = await import("../src/server/auth.js");
+    expect(auth.isWsOriginAllowed("http://tools.local:3000")).toBe(true);
+    expect(auth.isWsOriginAllowed("http://dash.local:5173")).toBe(true);
+    expect(auth.isWsOriginAllowed("http://evil.example")).toBe(false);
+  });
+
+  it("drops a wildcard CORS origin when token auth is disabled", async () => {
+    vi.stubEnv("AGMUX_CORS_ORIGINS", "*,http://dash.local:5173");
+    vi.spyOn(console, "error").mockImplementation(() => {});
+    vi.resetModules();
+    const config = await import("../src/server/config.js");
+    expect([...config.CORS_ALLOWED_ORIGINS]).toEqual(["http://dash.local:5173"]);
+  });
+});
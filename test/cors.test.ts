@@ -0,0 +1,87 @@
+import Fastify from "fastify";
+import { describe, expect, it } from "vitest";
+
+import { isCorsOriginAllowed, registerCorsHook, type CorsConfig } from "../src/server/cors.js";
+
+const config: CorsConfig = {
+  origins: new Set(["http://localhost:5173"]),
+  methods: ["GET", "POST"],
+  headers: ["Content-Type", "X-Agmux-Token"],
+};
+
+function buildApp(cfg: CorsConfig) {
+  const fastify = Fastify();
+  registerCorsHook(fastify, cfg);
+  fastify.get("/api/ptys", async () => ({ ptys: [] }));
+  fastify.get("/", async () => "ui");
+  return fastify;
+}
+
+describe("isCorsOriginAllowed", () => {
+  it("matches configured origins case-insensitively", () => {
+    expect(isCorsOriginAllowed(config, "HTTP://LOCALHOST:5173")).toBe(true);
+    expect(isCorsOriginAllowed(config, "http://evil.example")).toBe(false);
+    expect(isCorsOriginAllowed(config, undefined)).toBe(false);
+  });
+
+  it("allows any origin with a wildcard entry", () => {
+    expect(isCorsOriginAllowed({ ...config, origins: new Set(["*"]) }, "http://any.example")).toBe(true);
+  });
+});
+
+describe("registerCorsHook", () => {
+  it("adds no headers when no origins are configured", async () => {
+    const fastify = buildApp({ ...config, origins: new Set() });
+    const res = await fastify.inject({
+      method: "GET",
+      url: "/api/ptys",
+      headers: { origin: "http://localhost:5173" },
+    });
+    expect(res.headers["access-control-allow-origin"]).toBeUndefined();
+    await fastify.close();
+  });
+
+  it("echoes allowed origins on API responses", async () => {
+    const fastify = buildApp(config);
+    const res = await fastify.inject({
+      method: "GET",
+      url: "/api/ptys",
+      headers: { origin: "http://localhost:5173" },
+    });
+    expect(res.statusCode).toBe(200);
+    expect(res.headers["access-control-allow-origin"]).toBe("http://localhost:5173");
+    expect(res.headers.vary).toContain("Origin");
+    await fastify.close();
+  });
+
+  it("answers preflight for allowed origins", async () => {
+    const fastify = buildApp(config);
+    const res = await fastify.inject({
+      method: "OPTIONS",
+      url: "/api/ptys",
+      headers: { origin: "http://localhost:5173", "access-control-request-method": "POST" },
+    });
+    expect(res.statusCode).toBe(204);
+    expect(res.headers["access-control-allow-methods"]).toBe("GET, POST");
+    expect(res.headers["access-control-allow-headers"]).toBe("Content-Type, X-Agmux-Token");
+    await fastify.close();
+  });
+
+  it("leaves disallowed origins and non-API paths alone", async () => {
+    const fastify = buildApp(config);
+    const denied = await fastify.inject({
+      method: "GET",
+      url: "/api/ptys",
+      headers: { origin: "http://evil.example" },
+    });
+    expect(denied.headers["access-control-allow-origin"]).toBeUndefined();
+
+    const page = await fastify.inject({
+      method: "GET",
+      url: "/",
+      headers: { origin: "http://localhost:5173" },
+    });
+    expect(page.headers["access-control-allow-origin"]).toBeUndefined();
+    await fastify.close();
+  });
+});
@@ -293,6 +293,19 @@ describe("defaultBranch: actual git repos", () => {
     const svc = makeService(repo.repoRoot);
     expect(await svc.defaultBranch(null)).toBe("main");
   });
+
+  test("worktreeStatus reports the branch of a worktree with no commits yet", async () => {
+    const repo = await createTempRepo("main");
+    cleanup = repo.cleanup;
+    const wtPath = path.join(repo.parentDir, "repo-orphan");
+    execFileSync("git", ["worktree", "add", wtPath, "-b", "feature"], { cwd: repo.repoRoot, stdio: "pipe" });
+    // Switch the linked worktree to an unborn branch: HEAD names it but has no commit.
+    execFileSync("git", ["checkout", "--orphan", "trunk"], { cwd: wtPath, stdio: "pipe" });
+
+    const svc = makeService(repo.repoRoot);
+    expect(svc.isKnownWorktreePath(wtPath)).toBe(true);
+    expect((await svc.worktreeStatus(wtPath)).branch).toBe("trunk");
+  });
 });
 
 // ---------------------------------------------------------------------------
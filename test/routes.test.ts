@@ -3,7 +3,9 @@ import WebSocket from "ws";
 import { describe, expect, it } from "vitest";
 
 import { registerAgentRoutes } from "../src/server/routes/agents.js";
+import { registerHealthRoutes } from "../src/server/routes/health.js";
 import { registerPtyRoutes } from "../src/server/routes/ptys.js";
+import { registerTriggerRoutes } from "../src/server/routes/triggers.js";
 import { registerWorktreeRoutes } from "../src/server/routes/worktrees.js";
 import { registerWs } from "../src/server/ws.js";
 import { WsHub } from "../src/ws/hub.js";
@@ -210,6 +212,80 @@ describe("route wiring", () => {
   });
 });
 
+describe("health routes", () => {
+  it("reports liveness without consulting readiness checks", async () => {
+    const fastify = Fastify();
+    registerHealthRoutes({
+      fastify,
+      readinessChecks: { db: async () => false },
+    });
+
+    const res = await fastify.inject({ method: "GET", url: "/healthz" });
+    expect(res.statusCode).toBe(200);
+    expect(res.json().ok).toBe(true);
+    await fastify.close();
+  });
+
+  it("returns 503 from /readyz when any check fails or throws", async () => {
+    const fastify = Fastify();
+    registerHealthRoutes({
+      fastify,
+      readinessChecks: {
+        healthy: async () => true,
+        db: async () => {
+          throw new Error("locked");
+        },
+      },
+    });
+
+    const res = await fastify.inject({ method: "GET", url: "/readyz" });
+    expect(res.statusCode).toBe(503);
+    expect(res.json()).toEqual({ ok: false, checks: { healthy: true, db: false } });
+    await fastify.close();
+  });
+
+  it("returns 200 from /readyz when all checks pass", async () => {
+    const fastify = Fastify();
+    registerHealthRoutes({
+      fastify,
+      readinessChecks: { db: async () => true },
+    });
+
+    const res = await fastify.inject({ method: "GET", url: "/readyz" });
+    expect(res.statusCode).toBe(200);
+    expect(res.json()).toEqual({ ok: true, checks: { db: true } });
+    await fastify.close();
+  });
+});
+
+describe("trigger reload route", () => {
+  it("reports loaded trigger count", async () => {
+    const fastify = Fastify();
+    registerTriggerRoutes({
+      fastify,
+      loadTriggersAndBroadcast: async () => ({ ok: true, version: 2, count: 3 }),
+    });
+
+    const res = await fastify.inject({ method: "POST", url: "/api/triggers/reload" });
+    expect(res.statusCode).toBe(200);
+    expect(res.json()).toEqual({ ok: true, version: 2, count: 3 });
+    await fastify.close();
+  });
+
+  it("surfaces reload failures instead of reporting success", async () => {
+    const fastify = Fastify();
+    registerTriggerRoutes({
+      fastify,
+      loadTriggersAndBroadcast: async () => ({ ok: false, error: "Trigger.name must be a string" }),
+    });
+
+    const res = await fastify.inject({ method: "POST", url: "/api/triggers/reload" });
+    expect(res.statusCode).toBe(500);
+    expect(res.json()).toEqual({ error: "Trigger.name must be a string" });
+    await fastify.close();
+  });
+});
+
 describe("ws wiring", () => {
   it("emits pty_list on connect", async () => {
     const fastify = Fastify();
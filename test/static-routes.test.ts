@@ -0,0 +1,116 @@
+import Fastify from "fastify";
+import fs from "node:fs";
+import os from "node:os";
+import path from "node:path";
+import { afterEach, beforeEach, describe, expect, it } from "vitest";
+
+import { registerStaticRoutes } from "../src/server/routes/static.js";
+import { serveStatic } from "../src/server/static.js";
+
+describe("static routes", () => {
+  let publicDir: string;
+
+  beforeEach(() => {
+    publicDir = fs.mkdtempSync(path.join(os.tmpdir(), "agmux-static-"));
+    fs.writeFileSync(path.join(publicDir, "index.html"), "<!doctype html><title>agmux</title>");
+    fs.mkdirSync(path.join(publicDir, "icons"));
+    fs.writeFileSync(path.join(publicDir, "icons", "logo.svg"), "<svg></svg>");
+  });
+
+  afterEach(() => {
+    fs.rmSync(publicDir, { recursive: true, force: true });
+  });
+
+  it("serves nested assets with their content type", async () => {
+    const fastify = Fastify();
+    registerStaticRoutes({ fastify, publicDir });
+
+    const res = await fastify.inject({ method: "GET", url: "/icons/logo.svg" });
+    expect(res.statusCode).toBe(200);
+    expect(res.headers["content-type"]).toBe("image/svg+xml");
+    expect(res.headers["cache-control"]).toBe("no-cache");
+    expect(res.body).toBe("<svg></svg>");
+    await fastify.close();
+  });
+
+  it("answers matching If-None-Match with 304, including for /", async () => {
+    const fastify = Fastify();
+    registerStaticRoutes({ fastify, publicDir });
+
+    const first = await fastify.inject({ method: "GET", url: "/" });
+    const etag = first.headers.etag;
+    expect(typeof etag).toBe("string");
+
+    const second = await fastify.inject({
+      method: "GET",
+      url: "/",
+      headers: { "if-none-match": etag as string },
+    });
+    expect(second.statusCode).toBe(304);
+    await fastify.close();
+  });
+
+  it("falls back to index.html for extensionless paths only", async () => {
+    const fastify = Fastify();
+    registerStaticRoutes({ fastify, publicDir });
+
+    const page = await fastify.inject({ method: "GET", url: "/sessions/abc" });
+    expect(page.statusCode).toBe(200);
+    expect(page.body).toContain("<title>agmux</title>");
+
+    const missing = await fastify.inject({ method: "GET", url: "/missing.js" });
+    expect(missing.statusCode).toBe(404);
+
+    const api = await fastify.inject({ method: "GET", url: "/api/unknown" });
+    expect(api.statusCode).toBe(404);
+    expect(api.headers["content-type"]).toContain("application/json");
+    expect(api.json().statusCode).toBe(404);
+    await fastify.close();
+  });
+
+  it("prefers the override directory and falls back to publicDir", async () => {
+    const overrideDir = fs.mkdtempSync(path.join(os.tmpdir(), "agmux-ui-override-"));
+    try {
+      fs.writeFileSync(path.join(overrideDir, "index.html"), "<!doctype html><title>custom</title>");
+      const fastify = Fastify();
+      registerStaticRoutes({ fastify, publicDir, overrideDir });
+
+      const index = await fastify.inject({ method: "GET", url: "/" });
+      expect(index.body).toContain("<title>custom</title>");
+
+      const logo = await fastify.inject({ method: "GET", url: "/icons/logo.svg" });
+      expect(logo.statusCode).toBe(200);
+      expect(logo.body).toBe("<svg></svg>");
+      await fastify.close();
+    } finally {
+      fs.rmSync(overrideDir, { recursive: true, force: true });
+    }
+  });
+
+  it("does not serve dotfiles or files under dot-directories", async () => {
+    fs.writeFileSync(path.join(publicDir, ".env"), "SECRET=1");
+    fs.mkdirSync(path.join(publicDir, ".git"));
+    fs.writeFileSync(path.join(publicDir, ".git", "config"), "[core]");
+    const fastify = Fastify();
+    registerStaticRoutes({ fastify, publicDir });
+
+    const env = await fastify.inject({ method: "GET", url: "/.env" });
+    expect(env.statusCode).toBe(404);
+    const gitConfig = await fastify.inject({ method: "GET", url: "/.git/config" });
+    expect(gitConfig.statusCode).toBe(404);
+    await fastify.close();
+  });
+
+  it("does not serve files from sibling directories sharing the prefix", async () => {
+    const sibling = `${publicDir}-other`;
+    try {
+      fs.mkdirSync(sibling);
+      fs.writeFileSync(path.join(sibling, "secret.txt"), "nope");
+
+      expect(await serveStatic(publicDir, `../${path.basename(sibling)}/secret.txt`)).toBeNull();
+      expect(await serveStatic(publicDir, "")).toBeNull();
+    } finally {
+      fs.rmSync(sibling, { recursive: true, force: true });
+    }
+  });
+});